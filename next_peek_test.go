@@ -0,0 +1,110 @@
+package bufiog
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestPeekGrowsBufferBeyondInitialSize(t *testing.T) {
+	data := bytes.Repeat([]byte("a"), 1000)
+	r := NewReaderSize[byte](bytes.NewReader(data), 16)
+
+	got, err := r.Peek(500)
+	if err != nil {
+		t.Fatalf("Peek(500) error: %v", err)
+	}
+	if len(got) != 500 {
+		t.Fatalf("Peek(500) returned %d elements, want 500", len(got))
+	}
+	if len(r.buf) < 500 {
+		t.Fatalf("buffer did not grow: len(buf) = %d, want >= 500", len(r.buf))
+	}
+	// Peeking does not advance the reader: the same data must still be
+	// readable from the front.
+	if got2, err := r.Peek(500); err != nil || !bytes.Equal(got, got2) {
+		t.Fatalf("second Peek(500) = %v, %v; want identical data, nil", got2, err)
+	}
+}
+
+func TestPeekGrowthReturnsShortDataAtEOF(t *testing.T) {
+	r := NewReaderSize[byte](bytes.NewReader([]byte("short")), 16)
+	got, err := r.Peek(500)
+	if len(got) != 5 || string(got) != "short" {
+		t.Fatalf("Peek(500) = %q; want \"short\"", got)
+	}
+	if err == nil {
+		t.Fatal("Peek(500) past EOF should report an error")
+	}
+}
+
+func TestNextReturnsExactlyNAndAdvances(t *testing.T) {
+	r := NewReaderSize[byte](bytes.NewReader([]byte("hello world")), 16)
+
+	got, err := r.Next(5)
+	if err != nil {
+		t.Fatalf("Next(5) error: %v", err)
+	}
+	if string(got) != "hello" {
+		t.Fatalf("Next(5) = %q, want \"hello\"", got)
+	}
+	if r.Buffered() != len("hello world")-5 {
+		t.Fatalf("Buffered() = %d, want %d", r.Buffered(), len("hello world")-5)
+	}
+
+	// The second call continues where the first stopped, returning the
+	// rest of the buffered data.
+	rest, err := r.Next(6)
+	if err != nil {
+		t.Fatalf("Next(6) error: %v", err)
+	}
+	if string(rest) != " world" {
+		t.Fatalf("Next(6) = %q, want \" world\"", rest)
+	}
+}
+
+func TestNextClearsLastElem(t *testing.T) {
+	r := NewReaderSize[byte](bytes.NewReader([]byte("ab")), 16)
+	if _, err := r.ReadElem(); err != nil {
+		t.Fatalf("ReadElem error: %v", err)
+	}
+	if _, err := r.Next(1); err != nil {
+		t.Fatalf("Next(1) error: %v", err)
+	}
+	if err := r.UnreadElem(); err != ErrInvalidUnreadElem {
+		t.Fatalf("UnreadElem after Next = %v, want ErrInvalidUnreadElem", err)
+	}
+}
+
+func TestNextGrowsBufferLikePeek(t *testing.T) {
+	data := bytes.Repeat([]byte("b"), 1000)
+	r := NewReaderSize[byte](bytes.NewReader(data), 16)
+
+	got, err := r.Next(500)
+	if err != nil {
+		t.Fatalf("Next(500) error: %v", err)
+	}
+	if len(got) != 500 {
+		t.Fatalf("Next(500) returned %d elements, want 500", len(got))
+	}
+	if len(r.buf) < 500 {
+		t.Fatalf("buffer did not grow: len(buf) = %d, want >= 500", len(r.buf))
+	}
+	if want := len(r.buf) - 500; r.Buffered() != want {
+		t.Fatalf("Buffered() = %d, want %d (grown buffer filled in one read, 500 consumed)", r.Buffered(), want)
+	}
+}
+
+func TestNextShortAtEOFReportsError(t *testing.T) {
+	r := NewReaderSize[byte](bytes.NewReader([]byte("hi")), 16)
+	got, err := r.Next(10)
+	if len(got) != 2 || string(got) != "hi" {
+		t.Fatalf("Next(10) = %q, want \"hi\"", got)
+	}
+	if err == nil {
+		t.Fatal("Next(10) past EOF should report an error")
+	}
+	if _, err := r.ReadElem(); err != io.EOF {
+		t.Fatalf("ReadElem after draining = %v, want io.EOF", err)
+	}
+}
@@ -0,0 +1,51 @@
+package bufiog
+
+import (
+	"reflect"
+	"sync"
+)
+
+// poolKey identifies a shared buffer pool by element type and size class.
+type poolKey struct {
+	typ  reflect.Type
+	size int
+}
+
+// bufPools holds one *sync.Pool per (element type, size class) pair,
+// shared across all Reader[T] instantiations so that transient buffers
+// can be recycled instead of reallocated on every short-lived Reader.
+var bufPools sync.Map // poolKey -> *sync.Pool
+
+// poolFor returns the shared buffer pool for buffers of the given size
+// class holding elements of type T, creating it on first use. size is
+// rounded up to a size class via poolSizeClass, so callers requesting
+// arbitrarily many distinct sizes (e.g. length-prefixed frames of varying
+// length) still only ever populate a handful of pools.
+func poolFor[T any](size int) *sync.Pool {
+	size = poolSizeClass(size)
+	key := poolKey{typ: reflect.TypeOf((*T)(nil)).Elem(), size: size}
+	if p, ok := bufPools.Load(key); ok {
+		return p.(*sync.Pool)
+	}
+	p, _ := bufPools.LoadOrStore(key, &sync.Pool{
+		New: func() any { return make([]T, size) },
+	})
+	return p.(*sync.Pool)
+}
+
+// poolSizeClass rounds size up to the next power of two, so that buffers
+// of nearby sizes share a pool instead of each distinct size permanently
+// growing bufPools by one entry.
+func poolSizeClass(size int) int {
+	if size < 1 {
+		return 1
+	}
+	size--
+	size |= size >> 1
+	size |= size >> 2
+	size |= size >> 4
+	size |= size >> 8
+	size |= size >> 16
+	size |= size >> 32
+	return size + 1
+}
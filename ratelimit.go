@@ -0,0 +1,159 @@
+package bufiog
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrInvalidRate is returned by RateLimitedReader.Read and
+// RateLimitedWriter.Write when the configured rate is not positive, since
+// a bucket that never refills would otherwise block forever waiting for
+// a token that can never arrive.
+var ErrInvalidRate = errors.New("bufiog: rate must be positive")
+
+// tokenBucket implements the token-bucket accounting shared by
+// RateLimitedReader and RateLimitedWriter: tokens accumulate at rate
+// elements per second, up to a cap of burst, and are spent one per
+// element transferred.
+type tokenBucket struct {
+	rate   float64 // elements per second
+	burst  int     // bucket capacity, in elements
+	tokens float64 // tokens currently available
+	last   time.Time
+}
+
+// newTokenBucket returns a full bucket for the given rate and burst. A
+// burst below 1 is raised to 1, since a bucket that can never hold a
+// token would block forever.
+func newTokenBucket(rate float64, burst int) tokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return tokenBucket{rate: rate, burst: burst, tokens: float64(burst), last: time.Now()}
+}
+
+// setRate changes the allowed elements-per-second rate. The bucket's
+// accumulated tokens are left untouched.
+func (b *tokenBucket) setRate(rate float64) { b.rate = rate }
+
+// refill adds tokens for the time elapsed since the last refill, clamped
+// to the bucket's burst capacity.
+func (b *tokenBucket) refill() {
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if max := float64(b.burst); b.tokens > max {
+		b.tokens = max
+	}
+	b.last = now
+}
+
+// wait refills the bucket and blocks until at least one token is
+// available. It reports ErrInvalidRate instead of blocking forever if
+// the rate can never produce a token.
+func (b *tokenBucket) wait() error {
+	if b.rate <= 0 {
+		return ErrInvalidRate
+	}
+	b.refill()
+	for b.tokens < 1 {
+		time.Sleep(b.tokenWait())
+		b.refill()
+	}
+	return nil
+}
+
+// tokenWait returns how long to sleep for a single token to become
+// available at the current rate. Only called while rate is known
+// positive; see wait.
+func (b *tokenBucket) tokenWait() time.Duration {
+	wait := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+	if wait <= 0 {
+		wait = time.Millisecond
+	}
+	return wait
+}
+
+// RateLimitedReader wraps a ReadInterface[T] and throttles it to a
+// caller-supplied elements-per-second cap using a token-bucket algorithm.
+// Each Read blocks until at least one token is available, then delegates
+// to the underlying reader for at most as many elements as there are
+// tokens in the bucket.
+type RateLimitedReader[T any] struct {
+	r ReadInterface[T]
+	tokenBucket
+}
+
+// NewRateLimitedReader returns a RateLimitedReader that reads from r,
+// allowing at most rate elements per second with bursts of up to burst
+// elements. The bucket starts full.
+func NewRateLimitedReader[T any](r ReadInterface[T], rate float64, burst int) *RateLimitedReader[T] {
+	return &RateLimitedReader[T]{r: r, tokenBucket: newTokenBucket(rate, burst)}
+}
+
+// SetRate changes the allowed elements-per-second rate for subsequent
+// reads. The bucket's accumulated tokens are left untouched.
+func (r *RateLimitedReader[T]) SetRate(rate float64) { r.setRate(rate) }
+
+// Read reads at most len(p) elements from the underlying reader, blocking
+// until the token bucket has at least one token available and capping
+// the read to the number of tokens on hand.
+func (r *RateLimitedReader[T]) Read(p []T) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if err := r.wait(); err != nil {
+		return 0, err
+	}
+	if n := int(r.tokens); n < len(p) {
+		p = p[:n]
+	}
+	n, err := r.r.Read(p)
+	r.tokens -= float64(n)
+	return n, err
+}
+
+// RateLimitedWriter wraps a WriteInterface[T] and throttles it to a
+// caller-supplied elements-per-second cap using a token-bucket algorithm.
+// Each Write blocks as needed so that, averaged over time, no more than
+// rate elements per second are written to the underlying writer.
+type RateLimitedWriter[T any] struct {
+	w WriteInterface[T]
+	tokenBucket
+}
+
+// NewRateLimitedWriter returns a RateLimitedWriter that writes to w,
+// allowing at most rate elements per second with bursts of up to burst
+// elements. The bucket starts full.
+func NewRateLimitedWriter[T any](w WriteInterface[T], rate float64, burst int) *RateLimitedWriter[T] {
+	return &RateLimitedWriter[T]{w: w, tokenBucket: newTokenBucket(rate, burst)}
+}
+
+// SetRate changes the allowed elements-per-second rate for subsequent
+// writes. The bucket's accumulated tokens are left untouched.
+func (w *RateLimitedWriter[T]) SetRate(rate float64) { w.setRate(rate) }
+
+// Write writes all of p to the underlying writer, blocking as necessary
+// to stay within the configured rate. If nn < len(p), it also returns an
+// error explaining why the write is short.
+func (w *RateLimitedWriter[T]) Write(p []T) (nn int, err error) {
+	for len(p) > 0 {
+		if err := w.wait(); err != nil {
+			return nn, err
+		}
+		chunk := p
+		if n := int(w.tokens); n < len(chunk) {
+			chunk = chunk[:n]
+		}
+		n, err := w.w.Write(chunk)
+		w.tokens -= float64(n)
+		nn += n
+		p = p[n:]
+		if n < len(chunk) && err == nil {
+			err = ErrShortWrite
+		}
+		if err != nil {
+			return nn, err
+		}
+	}
+	return nn, nil
+}
@@ -0,0 +1,113 @@
+package bufiog
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRateLimitedReaderThrottlesAndCompletes(t *testing.T) {
+	rlr := NewRateLimitedReader[byte](strings.NewReader("hello world"), 1000, 4)
+	buf := make([]byte, 64)
+	start := time.Now()
+	total := 0
+	for {
+		n, err := rlr.Read(buf[total:])
+		total += n
+		if err != nil {
+			break
+		}
+	}
+	if total != len("hello world") {
+		t.Fatalf("got %d bytes, want %d", total, len("hello world"))
+	}
+	if time.Since(start) > 500*time.Millisecond {
+		t.Fatalf("took too long: %v", time.Since(start))
+	}
+}
+
+func TestRateLimitedReaderZeroBurstDoesNotDeadlock(t *testing.T) {
+	rlr := NewRateLimitedReader[byte](strings.NewReader("hi"), 1000, 0)
+	done := make(chan struct{})
+	go func() {
+		buf := make([]byte, 2)
+		rlr.Read(buf)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Read deadlocked with burst=0")
+	}
+}
+
+func TestRateLimitedReaderNonPositiveRateReturnsError(t *testing.T) {
+	rlr := NewRateLimitedReader[byte](strings.NewReader("hi"), 0, 4)
+	done := make(chan error, 1)
+	go func() {
+		buf := make([]byte, 2)
+		_, err := rlr.Read(buf)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != ErrInvalidRate {
+			t.Fatalf("Read error = %v, want ErrInvalidRate", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Read deadlocked with rate<=0 instead of returning ErrInvalidRate")
+	}
+}
+
+func TestRateLimitedReaderSetRateToZeroLaterReturnsError(t *testing.T) {
+	rlr := NewRateLimitedReader[byte](strings.NewReader("hello"), 1000, 4)
+	rlr.SetRate(0)
+	done := make(chan error, 1)
+	go func() {
+		buf := make([]byte, 2)
+		_, err := rlr.Read(buf)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != ErrInvalidRate {
+			t.Fatalf("Read error = %v, want ErrInvalidRate", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Read deadlocked after SetRate(0) instead of returning ErrInvalidRate")
+	}
+}
+
+type shortWriter struct{}
+
+func (shortWriter) Write(p []byte) (int, error) {
+	if len(p) > 1 {
+		return 1, nil
+	}
+	return len(p), nil
+}
+
+func TestRateLimitedWriterShortWriteReturnsErrShortWrite(t *testing.T) {
+	rlw := NewRateLimitedWriter[byte](shortWriter{}, 1000, 10)
+	_, err := rlw.Write([]byte("ab"))
+	if err != ErrShortWrite {
+		t.Fatalf("got err %v, want ErrShortWrite", err)
+	}
+}
+
+func TestRateLimitedWriterNonPositiveRateReturnsError(t *testing.T) {
+	rlw := NewRateLimitedWriter[byte](shortWriter{}, -1, 4)
+	done := make(chan error, 1)
+	go func() {
+		_, err := rlw.Write([]byte("hi"))
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != ErrInvalidRate {
+			t.Fatalf("Write error = %v, want ErrInvalidRate", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Write deadlocked with rate<0 instead of returning ErrInvalidRate")
+	}
+}
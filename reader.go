@@ -36,7 +36,8 @@ type Reader[T any] struct {
 	rd       ReadInterface[T] // reader provided by the client
 	r, w     int              // buf read and write positions
 	err      error
-	lastElem *T // last element read for UnreadElem; nil means invalid
+	lastElem *T  // last element read for UnreadElem; nil means invalid
+	size     int // buf's pooled capacity; buf is nil when released to the pool
 }
 
 // NewReader returns a new Reader whose buffer has the default size.
@@ -50,14 +51,14 @@ func NewReader[T any](rd ReadInterface[T]) *Reader[T] {
 func NewReaderSize[T any](rd ReadInterface[T], size int) *Reader[T] {
 	// Is it already a Reader?
 	b, ok := rd.(*Reader[T])
-	if ok && len(b.buf) >= size {
+	if ok && b.size >= size {
 		return b
 	}
 	if size < minReadBufferSize {
 		size = minReadBufferSize
 	}
 	r := new(Reader[T])
-	r.reset(make([]T, size), rd)
+	r.reset(poolFor[T](size).Get().([]T), rd)
 	return r
 }
 
@@ -100,10 +101,24 @@ func (b *Reader[T]) Discard(n int) (discarded int, err error) {
 	}
 }
 
+// Next returns a slice containing the next n elements from the buffer,
+// advancing the reader as if the elements had been returned by Read. Like
+// Peek, Next hands back the reader's internal buffer directly rather than a
+// copy, so the returned slice is only valid until the next call to Read,
+// ReadElem, Peek, Next, or any other method that fills or discards the
+// buffer. If Next returns fewer than n elements, it also returns an error
+// explaining why the read is short.
+func (b *Reader[T]) Next(n int) ([]T, error) {
+	data, err := b.Peek(n)
+	b.r += len(data)
+	b.lastElem = nil
+	return data, err
+}
+
 // Peek returns the next n elements without advancing the reader. The elements stop
 // being valid at the next read call. If Peek returns fewer than n elements, it
-// also returns an error explaining why the read is short. The error is
-// ErrBufferFull if n is larger than b's buffer size.
+// also returns an error explaining why the read is short. If n is larger than
+// b's buffer size, the buffer is grown to hold at least n elements.
 //
 // Calling Peek prevents a UnreadElem call from succeeding
 // until the next read operation.
@@ -113,13 +128,14 @@ func (b *Reader[T]) Peek(n int) ([]T, error) {
 	}
 
 	b.lastElem = nil
+	b.ensureBuf()
 
-	for b.w-b.r < n && b.w-b.r < len(b.buf) && b.err == nil {
-		b.fill() // b.w-b.r < len(b.buf) => buffer is not full
+	if n > len(b.buf) {
+		b.grow(n)
 	}
 
-	if n > len(b.buf) {
-		return b.buf[b.r:b.w], ErrBufferFull
+	for b.w-b.r < n && b.w-b.r < len(b.buf) && b.err == nil {
+		b.fill() // b.w-b.r < len(b.buf) => buffer is not full
 	}
 
 	// 0 <= n <= len(b.buf)
@@ -152,8 +168,10 @@ func (b *Reader[T]) Read(p []T) (n int, err error) {
 	}
 	if b.r == b.w {
 		if b.err != nil {
+			b.releaseIfDrained()
 			return 0, b.readErr()
 		}
+		b.ensureBuf()
 		if len(p) >= len(b.buf) {
 			// Large read, empty buffer.
 			// Read directly into p to avoid copy.
@@ -164,6 +182,7 @@ func (b *Reader[T]) Read(p []T) (n int, err error) {
 			if n > 0 {
 				b.lastElem = &p[n-1]
 			}
+			b.releaseIfDrained()
 			return n, b.readErr()
 		}
 		// One read.
@@ -175,6 +194,7 @@ func (b *Reader[T]) Read(p []T) (n int, err error) {
 			panic(errNegativeRead)
 		}
 		if n == 0 {
+			b.releaseIfDrained()
 			return 0, b.readErr()
 		}
 		b.w += n
@@ -186,6 +206,7 @@ func (b *Reader[T]) Read(p []T) (n int, err error) {
 	n = copy(p, b.buf[b.r:b.w])
 	b.r += n
 	b.lastElem = &b.buf[b.r-1]
+	b.releaseIfDrained()
 	return n, nil
 }
 
@@ -218,18 +239,55 @@ func (b *Reader[T]) Reset(r ReadInterface[T]) {
 		return
 	}
 	if b.buf == nil {
-		b.buf = make([]T, defaultBufSize)
+		size := b.size
+		if size == 0 {
+			size = defaultBufSize
+		}
+		b.buf = poolFor[T](size).Get().([]T)
 	}
 	b.reset(b.buf, r)
 }
 
+// Skip skips the next n elements, returning an error if fewer than n
+// elements could be skipped.
+//
+// If T is byte and the underlying reader implements io.Seeker, Skip
+// discards the buffered elements and issues a single Seek for the
+// remainder instead of reading and discarding it, making it cheap to skip
+// arbitrarily large spans of unread data. Otherwise Skip behaves exactly
+// like Discard.
+func (b *Reader[T]) Skip(n int64) error {
+	if n < 0 {
+		return ErrNegativeCount
+	}
+
+	if seeker, ok := b.seeker(); ok && n > int64(b.Buffered()) {
+		remaining := n - int64(b.Buffered())
+		b.r, b.w = 0, 0
+		b.lastElem = nil
+		// The seek moves to a new position, so any error recorded
+		// against the old one (e.g. a transient read error left
+		// unsurfaced by a prior Peek) no longer applies.
+		b.err = nil
+		_, err := seeker.Seek(remaining, io.SeekCurrent)
+		if err != nil {
+			b.err = err
+		}
+		return err
+	}
+
+	_, err := b.Discard(int(n))
+	return err
+}
+
 // Size returns the length of the underlying buffer in elements.
-func (b *Reader[T]) Size() int { return len(b.buf) }
+func (b *Reader[T]) Size() int { return b.size }
 
 func (b *Reader[T]) UnreadElem() error {
 	if b.lastElem == nil || b.r == 0 && b.w > 0 {
 		return ErrInvalidUnreadElem
 	}
+	b.ensureBuf()
 	// b.r > 0 || b.w == 0
 	if b.r > 0 {
 		b.r--
@@ -287,8 +345,39 @@ func (b *Reader[T]) WriteTo(w WriteInterface[T]) (n int64, err error) {
 	return n, b.readErr()
 }
 
+// drainInto writes b's buffered and not-yet-read data to w, refilling
+// from the underlying reader as needed, until b is drained or a read or
+// write error stops it. It is the Reader-owned counterpart of
+// Writer.ReadFrom's *Reader[T] fast path, keeping the invalidation rules
+// for b's internal state (clearing lastElem, releasing a drained buffer
+// to the pool via fill) in one place instead of duplicated in writer.go.
+func (b *Reader[T]) drainInto(w WriteInterface[T]) (n int64, err error) {
+	b.lastElem = nil
+	for {
+		if b.r == b.w {
+			if b.err != nil {
+				err = b.readErr()
+				break
+			}
+			b.fill()
+			continue
+		}
+		m, werr := b.writeBuf(w)
+		n += m
+		if werr != nil {
+			return n, werr
+		}
+	}
+	if err == io.EOF {
+		err = nil
+	}
+	return n, err
+}
+
 // fill reads a new chunk into the buffer.
 func (b *Reader[T]) fill() {
+	b.ensureBuf()
+
 	// Slide existing data to beginning.
 	if b.r > 0 {
 		copy(b.buf, b.buf[b.r:b.w])
@@ -309,6 +398,7 @@ func (b *Reader[T]) fill() {
 		b.w += n
 		if err != nil {
 			b.err = err
+			b.releaseIfDrained()
 			return
 		}
 		if n > 0 {
@@ -317,16 +407,75 @@ func (b *Reader[T]) fill() {
 	}
 	b.err = io.ErrNoProgress
 }
+
+// releaseIfDrained returns buf to the shared pool if the reader has no
+// buffered data left and the underlying reader has reported io.EOF. fill
+// and every return path of Read call it, so the pooled buffer is returned
+// whether EOF was first seen via fill, a direct Read, or a pending sticky
+// error observed by Read. Other methods that consume the last buffered
+// element after EOF has been recorded (ReadElem, for one) return the
+// pending EOF without calling it, leaving buf held until a later fill or
+// Read.
+func (b *Reader[T]) releaseIfDrained() {
+	if b.r == b.w && b.err == io.EOF {
+		b.release()
+	}
+}
+
+// ensureBuf lazily reacquires buf from the shared pool if it was
+// previously returned by release.
+func (b *Reader[T]) ensureBuf() {
+	if b.buf == nil {
+		b.buf = poolFor[T](b.size).Get().([]T)
+	}
+}
+
+// grow reallocates b.buf to hold at least n elements, preserving any
+// buffered but unread data. The new size is rounded up to the same size
+// class poolFor uses, so a later release puts the buffer back in the
+// pool bucket that actually matches its length.
+func (b *Reader[T]) grow(n int) {
+	n = poolSizeClass(n)
+	buf := make([]T, n)
+	b.w = copy(buf, b.buf[b.r:b.w])
+	b.r = 0
+	b.buf = buf
+	b.size = n
+}
 func (b *Reader[T]) readErr() error {
 	err := b.err
 	b.err = nil
 	return err
 }
+
+// release returns buf to the shared pool once the reader has been fully
+// drained and the underlying reader has reported io.EOF, clearing b.buf
+// so it is lazily reacquired by ensureBuf on the next read.
+func (b *Reader[T]) release() {
+	if b.buf == nil {
+		return
+	}
+	poolFor[T](b.size).Put(b.buf)
+	b.buf = nil
+}
 func (b *Reader[T]) reset(buf []T, r ReadInterface[T]) {
 	*b = Reader[T]{
-		buf: buf,
-		rd:  r,
+		buf:  buf,
+		rd:   r,
+		size: len(buf),
+	}
+}
+
+// seeker reports whether the underlying reader can be skipped over with
+// Seek instead of being read and discarded: this requires T to be byte
+// and rd to implement io.Seeker.
+func (b *Reader[T]) seeker() (io.Seeker, bool) {
+	var zero T
+	if _, ok := any(zero).(byte); !ok {
+		return nil, false
 	}
+	s, ok := any(b.rd).(io.Seeker)
+	return s, ok
 }
 
 // writeBuf writes the Reader's buffer to the writer.
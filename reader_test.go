@@ -0,0 +1,218 @@
+package bufiog
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// drainErr is an io.Reader that returns a fixed number of bytes followed
+// by a caller-supplied error.
+type drainErr struct {
+	data []byte
+	err  error
+}
+
+func (d *drainErr) Read(p []byte) (int, error) {
+	if len(d.data) == 0 {
+		return 0, d.err
+	}
+	n := copy(p, d.data)
+	d.data = d.data[n:]
+	if len(d.data) == 0 {
+		return n, d.err
+	}
+	return n, nil
+}
+
+var errTransient = errors.New("transient read error")
+
+// seekReader is an io.ReadSeeker over an in-memory buffer whose first
+// Read call reports n > 0 alongside a non-EOF error, simulating a
+// transient error recorded alongside a short read. Every later call
+// serves plain data from the current position.
+type seekReader struct {
+	data      []byte
+	pos       int64
+	servedErr bool
+}
+
+func (s *seekReader) Read(p []byte) (int, error) {
+	n := copy(p, s.data[s.pos:])
+	s.pos += int64(n)
+	if !s.servedErr {
+		s.servedErr = true
+		if n > 0 {
+			return n, errTransient
+		}
+	}
+	if n == 0 {
+		return 0, io.EOF
+	}
+	return n, nil
+}
+
+func (s *seekReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+		s.pos = offset
+	case io.SeekCurrent:
+		s.pos += offset
+	case io.SeekEnd:
+		s.pos = int64(len(s.data)) + offset
+	}
+	return s.pos, nil
+}
+
+func TestReaderReleasesBufferOnReadEOF(t *testing.T) {
+	r := NewReaderSize[byte](strings.NewReader("hi"), 16)
+	buf := make([]byte, 2)
+	if n, err := r.Read(buf); n != 2 || err != nil {
+		t.Fatalf("Read = %d, %v; want 2, nil", n, err)
+	}
+	if _, err := r.Read(buf); err != io.EOF {
+		t.Fatalf("Read = %v; want io.EOF", err)
+	}
+	if r.buf != nil {
+		t.Fatal("Reader did not release its buffer to the pool on EOF")
+	}
+}
+
+func TestReaderReleasesBufferOnLargeReadEOF(t *testing.T) {
+	// len(p) >= len(b.buf) takes the direct-into-p fast path in Read,
+	// bypassing b.buf entirely.
+	r := NewReaderSize[byte](strings.NewReader("hi"), 16)
+	buf := make([]byte, 32)
+	n, err := r.Read(buf)
+	if n != 2 || (err != nil && err != io.EOF) {
+		t.Fatalf("Read = %d, %v; want 2, nil or io.EOF", n, err)
+	}
+	if err == nil {
+		if _, err = r.Read(buf); err != io.EOF {
+			t.Fatalf("Read = %v; want io.EOF", err)
+		}
+	}
+	if r.buf != nil {
+		t.Fatal("Reader did not release its buffer to the pool on EOF")
+	}
+}
+
+func TestReaderReleasesBufferOnPendingError(t *testing.T) {
+	// A short read that reports n>0 alongside io.EOF leaves a sticky
+	// error for the *next* Read to observe with an already-empty buffer.
+	r := NewReaderSize[byte](&drainErr{data: []byte("hi"), err: io.EOF}, 16)
+	buf := make([]byte, 1)
+	for {
+		if _, err := r.Read(buf); err != nil {
+			break
+		}
+	}
+	if r.buf != nil {
+		t.Fatal("Reader did not release its buffer once the pending EOF was observed")
+	}
+}
+
+func TestReaderResetReacquiresBufferFromPool(t *testing.T) {
+	r := NewReaderSize[byte](strings.NewReader("hi"), 16)
+	io.ReadAll(r)
+	if r.buf != nil {
+		t.Fatal("setup: expected buffer to be released")
+	}
+	r.Reset(strings.NewReader("bye"))
+	got, err := io.ReadAll(r)
+	if err != nil || string(got) != "bye" {
+		t.Fatalf("ReadAll after Reset = %q, %v; want \"bye\", nil", got, err)
+	}
+}
+
+func TestPoolSizeClassBucketsNearbySizes(t *testing.T) {
+	small := poolFor[byte](100)
+	large := poolFor[byte](128)
+	if small != large {
+		t.Fatal("poolFor(100) and poolFor(128) should share the same size-class pool")
+	}
+	if got := poolSizeClass(129); got != 256 {
+		t.Fatalf("poolSizeClass(129) = %d, want 256", got)
+	}
+	if got := poolSizeClass(1); got != 1 {
+		t.Fatalf("poolSizeClass(1) = %d, want 1", got)
+	}
+}
+
+func TestPoolReusedAcrossManyFrameSizes(t *testing.T) {
+	// Simulates the length-prefixed-frame workload the pooling feature
+	// targets: many short-lived Readers, each Peeking a distinct size,
+	// must not each mint a permanent, never-reused pool entry.
+	seen := map[*sync.Pool]bool{}
+	for size := 1; size <= 200; size++ {
+		seen[poolFor[byte](size)] = true
+	}
+	if len(seen) > 9 { // nine size classes: 1, 2, 4, ..., 256
+		t.Fatalf("got %d distinct pools for 200 distinct sizes, want a small bounded number", len(seen))
+	}
+}
+
+func TestReaderGrowReusesPoolSizeClass(t *testing.T) {
+	r := NewReaderSize[byte](bytes.NewReader(make([]byte, 1000)), 16)
+	if _, err := r.Peek(100); err != nil && !errors.Is(err, io.EOF) {
+		t.Fatalf("Peek(100) error: %v", err)
+	}
+	if r.size != poolSizeClass(100) {
+		t.Fatalf("after grow, size = %d, want %d", r.size, poolSizeClass(100))
+	}
+}
+
+func TestSkipSeekClearsPendingError(t *testing.T) {
+	data := append([]byte("12345678"), bytes.Repeat([]byte("x"), 100)...)
+	sr := &seekReader{data: data}
+	r := NewReaderSize[byte](sr, 16)
+
+	// Peek fills the buffer via a Read that reports a transient,
+	// non-EOF error alongside real data. Since the buffer already has
+	// enough data to satisfy the Peek, the error is left pending and
+	// unsurfaced.
+	if _, err := r.Peek(8); err != nil {
+		t.Fatalf("Peek(8) = %v; want nil", err)
+	}
+
+	// Skip past the buffered data, large enough to take the Seek fast
+	// path instead of Discard.
+	if err := r.Skip(int64(len(data)) - 8); err != nil {
+		t.Fatalf("Skip = %v; want nil", err)
+	}
+
+	// The stale transient error must not resurface on the next read at
+	// the new position.
+	c, err := r.ReadElem()
+	if err != nil {
+		t.Fatalf("ReadElem after Skip = %v; want nil (stale error leaked)", err)
+	}
+	if c != 'x' {
+		t.Fatalf("ReadElem after Skip = %q; want 'x'", c)
+	}
+}
+
+func TestReadFromFastPathClearsLastElem(t *testing.T) {
+	src := NewReaderSize[byte](strings.NewReader("hello"), 16)
+	if _, err := src.ReadElem(); err != nil {
+		t.Fatalf("ReadElem error: %v", err)
+	}
+	dst := NewWriterSize[byte](&bytes.Buffer{}, 16)
+
+	n, err := dst.ReadFrom(src)
+	if err != nil {
+		t.Fatalf("ReadFrom error: %v", err)
+	}
+	if n != 4 {
+		t.Fatalf("ReadFrom = %d, want 4", n)
+	}
+	if src.buf != nil {
+		t.Fatal("setup: expected src's buffer to be released to the pool on EOF")
+	}
+	if err := src.UnreadElem(); err != ErrInvalidUnreadElem {
+		t.Fatalf("UnreadElem after ReadFrom drained src = %v, want ErrInvalidUnreadElem", err)
+	}
+}
@@ -0,0 +1,266 @@
+package bufiog
+
+import (
+	"errors"
+	"io"
+)
+
+// SplitFunc is the signature of the split function used to tokenize the
+// input. The arguments are an initial substring of the remaining unprocessed
+// data and a flag, atEOF, that reports whether the Reader has no more data
+// to give. The return values are the number of elements to advance the
+// input and the next token to return to the caller, if any, plus an error,
+// if any.
+//
+// Scanning stops if the function returns an error, in which case some of
+// the input may be discarded. If that error is ErrFinalToken, scanning
+// stops with no error.
+//
+// Otherwise, the Scanner advances the input. If the token is not nil, the
+// Scanner returns it to the caller. If the token is nil, the Scanner reads
+// more data and continues scanning; if there is no more data -- if atEOF
+// was true -- the Scanner returns. If the data does not yet hold a
+// complete token, a SplitFunc can return (0, nil, nil) to signal the
+// Scanner to read more data into the slice and try again with a longer
+// slice starting at the same point in the input.
+//
+// The function is never called with an empty data slice unless atEOF is
+// true. If atEOF is true, however, data may be non-empty and, as always,
+// holds unprocessed elements.
+type SplitFunc[T any] func(data []T, atEOF bool) (advance int, token []T, err error)
+
+// Errors returned by Scanner.
+var (
+	ErrTooLong         = errors.New("bufiog: token too long")
+	ErrNegativeAdvance = errors.New("bufiog: split func returned negative advance count")
+	ErrAdvanceTooFar   = errors.New("bufiog: split func returned advance count beyond input")
+	ErrBadReadCount    = errors.New("bufiog: read returned impossible count")
+)
+
+// ErrFinalToken is a special sentinel error value. It is intended to be
+// returned by a SplitFunc to indicate that the token being delivered with
+// the error is the last token and scanning should stop after this one.
+// After ErrFinalToken is received by Scan, scanning stops with no error.
+var ErrFinalToken = errors.New("bufiog: final token")
+
+const (
+	// MaxScanTokenSize is the maximum size used to buffer a token unless
+	// the caller provides an explicit buffer with Scanner.Buffer.
+	MaxScanTokenSize = 64 * 1024
+
+	startScanBufSize = 4096 // Size of initial allocation for buf.
+)
+
+// Scanner provides a convenient interface for reading a stream of T broken
+// into tokens, such as delimited frames over a channel of messages or
+// newline-separated lines of text. Successive calls to the Scan method
+// step through the tokens of the input, skipping any elements between
+// tokens. The specification of a token is defined by a SplitFunc; the
+// default split function, SplitElems, returns each element as its own
+// token.
+//
+// Scanning stops unrecoverably at EOF, the first I/O error, or a token too
+// large to fit in the buffer. When a scan stops, the reader may have
+// advanced arbitrarily far past the last token.
+type Scanner[T any] struct {
+	r            ReadInterface[T]
+	split        SplitFunc[T]
+	maxTokenSize int
+	token        []T
+	buf          []T
+	start        int // first non-processed element in buf
+	end          int // end of data in buf
+	err          error
+	empties      int // count of successive empty tokens
+	scanCalled   bool
+	done         bool
+}
+
+// NewScanner returns a new Scanner to read from r. The split function
+// defaults to SplitElems.
+func NewScanner[T any](r ReadInterface[T]) *Scanner[T] {
+	return &Scanner[T]{
+		r:            r,
+		split:        SplitElems[T],
+		maxTokenSize: MaxScanTokenSize,
+	}
+}
+
+// Err returns the first non-EOF error encountered by the Scanner.
+func (s *Scanner[T]) Err() error {
+	if s.err == io.EOF {
+		return nil
+	}
+	return s.err
+}
+
+// Elems returns the most recent token generated by a call to Scan. The
+// underlying array may point to data that will be overwritten by a
+// subsequent call to Scan. It does no allocation.
+func (s *Scanner[T]) Elems() []T {
+	return s.token
+}
+
+// Scan advances the Scanner to the next token, which will then be
+// available through the Elems method. It returns false when the scan
+// stops, either by reaching the end of the input or an error. After Scan
+// returns false, the Err method will return any error that occurred
+// during scanning, except that if it was io.EOF, Err will return nil.
+// Scan panics if the split function returns too many empty tokens without
+// advancing the input. This is a common error mode for scanners.
+func (s *Scanner[T]) Scan() bool {
+	if s.done {
+		return false
+	}
+	s.scanCalled = true
+	for {
+		// See if we can get a token with what we already have.
+		// If we've run out of data but have an error, give the split
+		// function a chance to recover any remaining, possibly empty
+		// token.
+		if s.end > s.start || s.err != nil {
+			advance, token, err := s.split(s.buf[s.start:s.end], s.err != nil)
+			if err != nil {
+				if err == ErrFinalToken {
+					s.token = token
+					s.done = true
+					return true
+				}
+				s.setErr(err)
+				return false
+			}
+			if !s.advance(advance) {
+				return false
+			}
+			s.token = token
+			if token != nil {
+				if s.err == nil || advance > 0 {
+					s.empties = 0
+				} else {
+					// Returning tokens not advancing input at EOF.
+					s.empties++
+					if s.empties > maxConsecutiveEmptyReads {
+						panic("bufiog: Scan: too many empty tokens without progressing")
+					}
+				}
+				return true
+			}
+		}
+		// We cannot generate a token with what we are holding.
+		// If we've already hit EOF or an I/O error, we are done.
+		if s.err != nil {
+			s.start = 0
+			s.end = 0
+			return false
+		}
+		// Must read more data.
+		// First, shift data to beginning of buf if there's lots of empty
+		// space or space is needed.
+		if s.start > 0 && (s.end == len(s.buf) || s.start > len(s.buf)/2) {
+			copy(s.buf, s.buf[s.start:s.end])
+			s.end -= s.start
+			s.start = 0
+		}
+		// Is the buffer full? If so, resize.
+		if s.end == len(s.buf) {
+			if len(s.buf) >= s.maxTokenSize {
+				s.setErr(ErrTooLong)
+				return false
+			}
+			newSize := len(s.buf) * 2
+			if newSize == 0 {
+				newSize = startScanBufSize
+			}
+			if newSize > s.maxTokenSize {
+				newSize = s.maxTokenSize
+			}
+			newBuf := make([]T, newSize)
+			copy(newBuf, s.buf[s.start:s.end])
+			s.buf = newBuf
+			s.end -= s.start
+			s.start = 0
+		}
+		// Finally we can read some input. Make sure we don't get stuck
+		// with a misbehaving Reader.
+		for loop := 0; ; {
+			n, err := s.r.Read(s.buf[s.end:len(s.buf)])
+			if n < 0 || len(s.buf)-s.end < n {
+				s.setErr(ErrBadReadCount)
+				break
+			}
+			s.end += n
+			if err != nil {
+				s.setErr(err)
+				break
+			}
+			if n > 0 {
+				s.empties = 0
+				break
+			}
+			loop++
+			if loop > maxConsecutiveEmptyReads {
+				s.setErr(io.ErrNoProgress)
+				break
+			}
+		}
+	}
+}
+
+// advance consumes n elements of buf. It reports whether the advance was
+// legal.
+func (s *Scanner[T]) advance(n int) bool {
+	if n < 0 {
+		s.setErr(ErrNegativeAdvance)
+		return false
+	}
+	if n > s.end-s.start {
+		s.setErr(ErrAdvanceTooFar)
+		return false
+	}
+	s.start += n
+	return true
+}
+
+// setErr records the first error encountered.
+func (s *Scanner[T]) setErr(err error) {
+	if s.err == nil || s.err == io.EOF {
+		s.err = err
+	}
+}
+
+// Buffer sets the initial buffer to use when scanning and the maximum size
+// of buffer that may be allocated during scanning. The maximum token size
+// is the larger of max and cap(buf). If max <= cap(buf), Scan will use
+// this buffer only and do no allocation.
+//
+// By default, Scan uses an internal buffer and sets the maximum token
+// size to MaxScanTokenSize.
+//
+// Buffer panics if it is called after scanning has started.
+func (s *Scanner[T]) Buffer(buf []T, max int) {
+	if s.scanCalled {
+		panic("bufiog: Buffer called after Scan")
+	}
+	s.buf = buf[0:cap(buf)]
+	s.maxTokenSize = max
+}
+
+// Split sets the split function for the Scanner. The default split
+// function is SplitElems.
+//
+// Split panics if it is called after scanning has started.
+func (s *Scanner[T]) Split(split SplitFunc[T]) {
+	if s.scanCalled {
+		panic("bufiog: Split called after Scan")
+	}
+	s.split = split
+}
+
+// SplitElems is a split function for a Scanner that returns each element
+// as its own token.
+func SplitElems[T any](data []T, atEOF bool) (advance int, token []T, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	return 1, data[0:1], nil
+}
@@ -0,0 +1,100 @@
+package bufiog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSplitElemsTokenizesEachElement(t *testing.T) {
+	s := NewScanner[byte](strings.NewReader("abc"))
+	var got []byte
+	for s.Scan() {
+		got = append(got, s.Elems()...)
+	}
+	if err := s.Err(); err != nil {
+		t.Fatalf("Err() = %v, want nil", err)
+	}
+	if string(got) != "abc" {
+		t.Fatalf("scanned %q, want \"abc\"", got)
+	}
+}
+
+func TestScanGrowsBufferBeyondInitial(t *testing.T) {
+	data := bytes.Repeat([]byte("x"), 100)
+	s := NewScanner[byte](bytes.NewReader(data))
+	s.Buffer(make([]byte, 4), 1024)
+	s.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		if !atEOF {
+			// Request more data until everything has arrived, forcing
+			// the buffer to grow past its small initial capacity.
+			return 0, nil, nil
+		}
+		return len(data), data, nil
+	})
+	if !s.Scan() {
+		t.Fatalf("Scan() = false, want true; Err() = %v", s.Err())
+	}
+	if string(s.Elems()) != string(data) {
+		t.Fatalf("Elems() = %d bytes, want %d", len(s.Elems()), len(data))
+	}
+}
+
+func TestScanErrTooLong(t *testing.T) {
+	data := bytes.Repeat([]byte("y"), 100)
+	s := NewScanner[byte](bytes.NewReader(data))
+	s.Buffer(make([]byte, 4), 16)
+	s.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		// Never produces a token, forcing the buffer to keep growing
+		// until it exceeds the configured maximum.
+		return 0, nil, nil
+	})
+	if s.Scan() {
+		t.Fatal("Scan() = true, want false (token too long)")
+	}
+	if s.Err() != ErrTooLong {
+		t.Fatalf("Err() = %v, want ErrTooLong", s.Err())
+	}
+}
+
+func TestScanFinalTokenWithoutTrailingDelimiter(t *testing.T) {
+	s := NewScanner[byte](strings.NewReader("abc"))
+	s.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		if i := bytes.IndexByte(data, ','); i >= 0 {
+			return i + 1, data[:i], nil
+		}
+		if atEOF && len(data) > 0 {
+			return len(data), data, nil
+		}
+		return 0, nil, nil
+	})
+	if !s.Scan() {
+		t.Fatalf("Scan() = false, want true; Err() = %v", s.Err())
+	}
+	if string(s.Elems()) != "abc" {
+		t.Fatalf("Elems() = %q, want \"abc\"", s.Elems())
+	}
+	if s.Scan() {
+		t.Fatal("Scan() = true after the final token, want false")
+	}
+}
+
+func TestScanPanicsOnTooManyEmptyTokensAtEOF(t *testing.T) {
+	s := NewScanner[byte](strings.NewReader("a"))
+	s.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		if !atEOF {
+			return len(data), data, nil
+		}
+		// Return a non-nil token without advancing, forever, once the
+		// input is exhausted.
+		return 0, []byte{}, nil
+	})
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("Scan() did not panic on too many empty tokens without progress")
+		}
+	}()
+	for i := 0; i < maxConsecutiveEmptyReads+2; i++ {
+		s.Scan()
+	}
+}
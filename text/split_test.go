@@ -0,0 +1,89 @@
+package text
+
+import (
+	"testing"
+)
+
+func TestSplitLinesStripsCRLFAndHandlesFinalLine(t *testing.T) {
+	data := []byte("one\r\ntwo\nthree")
+
+	advance, token, err := SplitLines(data, false)
+	if err != nil || advance != 5 || string(token) != "one" {
+		t.Fatalf("SplitLines = %d, %q, %v; want 5, \"one\", nil", advance, token, err)
+	}
+	data = data[advance:]
+
+	advance, token, err = SplitLines(data, false)
+	if err != nil || advance != 4 || string(token) != "two" {
+		t.Fatalf("SplitLines = %d, %q, %v; want 4, \"two\", nil", advance, token, err)
+	}
+	data = data[advance:]
+
+	// "three" has no trailing newline, so it is only returned at EOF.
+	if advance, token, err = SplitLines(data, false); advance != 0 || token != nil || err != nil {
+		t.Fatalf("SplitLines (not at EOF) = %d, %q, %v; want 0, nil, nil", advance, token, err)
+	}
+	advance, token, err = SplitLines(data, true)
+	if err != nil || advance != len(data) || string(token) != "three" {
+		t.Fatalf("SplitLines (at EOF) = %d, %q, %v; want %d, \"three\", nil", advance, token, err, len(data))
+	}
+
+	if advance, token, err = SplitLines(nil, true); advance != 0 || token != nil || err != nil {
+		t.Fatalf("SplitLines(nil, true) = %d, %q, %v; want 0, nil, nil", advance, token, err)
+	}
+}
+
+func TestSplitWordsSkipsSpacesAndNeverReturnsEmpty(t *testing.T) {
+	data := []byte("  foo  bar")
+
+	advance, token, err := SplitWords(data, false)
+	if err != nil || string(token) != "foo" {
+		t.Fatalf("SplitWords = %d, %q, %v; want \"foo\"", advance, token, err)
+	}
+	data = data[advance:]
+
+	// "bar" has no trailing space, so it is only returned at EOF.
+	if _, token, err = SplitWords(data, false); token != nil || err != nil {
+		t.Fatalf("SplitWords (not at EOF) = %q, %v; want nil, nil", token, err)
+	}
+	advance, token, err = SplitWords(data, true)
+	if err != nil || advance != len(data) || string(token) != "bar" {
+		t.Fatalf("SplitWords (at EOF) = %d, %q, %v; want %d, \"bar\", nil", advance, token, err, len(data))
+	}
+
+	if advance, token, err := SplitWords([]byte("   "), true); advance != 3 || token != nil || err != nil {
+		t.Fatalf("SplitWords(all spaces, true) = %d, %q, %v; want 3, nil, nil", advance, token, err)
+	}
+}
+
+func TestSplitRunesHandlesASCIIMultibyteAndInvalidUTF8(t *testing.T) {
+	// ASCII fast path.
+	advance, token, err := SplitRunes([]byte("a"), false)
+	if err != nil || advance != 1 || string(token) != "a" {
+		t.Fatalf("SplitRunes(ascii) = %d, %q, %v; want 1, \"a\", nil", advance, token, err)
+	}
+
+	// Multi-byte UTF-8 fast path: "é" is 2 bytes.
+	data := []byte("é")
+	advance, token, err = SplitRunes(data, false)
+	if err != nil || advance != 2 || string(token) != "é" {
+		t.Fatalf("SplitRunes(multibyte) = %d, %q, %v; want 2, \"é\", nil", advance, token, err)
+	}
+
+	// An incomplete multi-byte sequence not at EOF asks for more data.
+	incomplete := data[:1]
+	if advance, token, err = SplitRunes(incomplete, false); advance != 0 || token != nil || err != nil {
+		t.Fatalf("SplitRunes(incomplete, not at EOF) = %d, %q, %v; want 0, nil, nil", advance, token, err)
+	}
+
+	// The same incomplete sequence at EOF is a real encoding error: it
+	// advances one byte and yields the replacement character.
+	advance, token, err = SplitRunes(incomplete, true)
+	if err != nil || advance != 1 || string(token) != "�" {
+		t.Fatalf("SplitRunes(incomplete, at EOF) = %d, %q, %v; want 1, U+FFFD, nil", advance, token, err)
+	}
+
+	if advance, token, err := SplitRunes(nil, true); advance != 0 || token != nil || err != nil {
+		t.Fatalf("SplitRunes(nil, true) = %d, %q, %v; want 0, nil, nil", advance, token, err)
+	}
+}
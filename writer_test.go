@@ -0,0 +1,172 @@
+package bufiog
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// plainReader exposes only Read, so it can't be mistaken for a *Reader[T].
+// Paired with a destination that does not implement ReadFromInterface[T],
+// it forces Writer.ReadFrom's generic fallback loop instead of either
+// fast path.
+type plainReader struct {
+	r *bytes.Reader
+}
+
+func (p *plainReader) Read(b []byte) (int, error) { return p.r.Read(b) }
+
+// errWriter always fails with a fixed error.
+type errWriter struct{ err error }
+
+func (e *errWriter) Write(p []byte) (int, error) { return 0, e.err }
+
+// shortNilErrWriter reports writing only one element per call without
+// an error, the case Flush must turn into ErrShortWrite.
+type shortNilErrWriter struct{ buf bytes.Buffer }
+
+func (w *shortNilErrWriter) Write(p []byte) (int, error) {
+	if len(p) > 1 {
+		p = p[:1]
+	}
+	w.buf.Write(p)
+	return len(p), nil
+}
+
+func TestWriterFlushPropagatesUnderlyingError(t *testing.T) {
+	wantErr := errors.New("boom")
+	w := NewWriterSize[byte](&errWriter{err: wantErr}, 8)
+	if err := w.WriteElem('a'); err != nil {
+		t.Fatalf("WriteElem error: %v", err)
+	}
+	if err := w.Flush(); err != wantErr {
+		t.Fatalf("Flush = %v, want %v", err, wantErr)
+	}
+	// Once recorded, the error is sticky for subsequent operations.
+	if err := w.WriteElem('b'); err != wantErr {
+		t.Fatalf("WriteElem after failed Flush = %v, want %v", err, wantErr)
+	}
+	if err := w.Flush(); err != wantErr {
+		t.Fatalf("Flush after failed Flush = %v, want %v", err, wantErr)
+	}
+}
+
+func TestWriterFlushShortWriteReturnsErrShortWrite(t *testing.T) {
+	w := NewWriterSize[byte](&shortNilErrWriter{}, 8)
+	if err := w.WriteElem('a'); err != nil {
+		t.Fatalf("WriteElem error: %v", err)
+	}
+	if err := w.WriteElem('b'); err != nil {
+		t.Fatalf("WriteElem error: %v", err)
+	}
+	if err := w.Flush(); err != ErrShortWrite {
+		t.Fatalf("Flush = %v, want ErrShortWrite", err)
+	}
+}
+
+func TestWriteElemFlushesWhenBufferFull(t *testing.T) {
+	var dst bytes.Buffer
+	w := NewWriterSize[byte](&dst, 2)
+	if err := w.WriteElem('a'); err != nil {
+		t.Fatalf("WriteElem error: %v", err)
+	}
+	if err := w.WriteElem('b'); err != nil {
+		t.Fatalf("WriteElem error: %v", err)
+	}
+	if dst.Len() != 0 {
+		t.Fatalf("dst.Len() = %d before buffer is full, want 0", dst.Len())
+	}
+	if err := w.WriteElem('c'); err != nil {
+		t.Fatalf("WriteElem error: %v", err)
+	}
+	if dst.String() != "ab" {
+		t.Fatalf("implicit Flush wrote %q, want \"ab\"", dst.String())
+	}
+	if w.Buffered() != 1 {
+		t.Fatalf("Buffered() = %d, want 1", w.Buffered())
+	}
+}
+
+func TestAvailableBufferAppendWritePattern(t *testing.T) {
+	var dst bytes.Buffer
+	w := NewWriterSize[byte](&dst, 16)
+
+	buf := w.AvailableBuffer()
+	if len(buf) != 0 || cap(buf) < w.Available() {
+		t.Fatalf("AvailableBuffer() = len %d cap %d, want len 0 cap >= %d", len(buf), cap(buf), w.Available())
+	}
+	buf = append(buf, 'x', 'y', 'z')
+
+	n, err := w.Write(buf)
+	if err != nil || n != 3 {
+		t.Fatalf("Write = %d, %v; want 3, nil", n, err)
+	}
+	if w.Buffered() != 3 {
+		t.Fatalf("Buffered() = %d, want 3", w.Buffered())
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush error: %v", err)
+	}
+	if dst.String() != "xyz" {
+		t.Fatalf("dst = %q, want \"xyz\"", dst.String())
+	}
+}
+
+func TestWriteLargeWriteBypassesBuffer(t *testing.T) {
+	var dst bytes.Buffer
+	w := NewWriterSize[byte](&dst, 4)
+	big := bytes.Repeat([]byte("m"), 100)
+
+	n, err := w.Write(big)
+	if err != nil || n != len(big) {
+		t.Fatalf("Write = %d, %v; want %d, nil", n, err, len(big))
+	}
+	if dst.String() != string(big) {
+		t.Fatal("large write did not bypass the buffer and go directly to the underlying writer")
+	}
+	if w.Buffered() != 0 {
+		t.Fatalf("Buffered() = %d, want 0", w.Buffered())
+	}
+}
+
+func TestWriterReadFromReaderFastPath(t *testing.T) {
+	data := bytes.Repeat([]byte("q"), 100)
+	src := NewReaderSize[byte](bytes.NewReader(data), 8)
+	var dst bytes.Buffer
+	w := NewWriterSize[byte](&dst, 8)
+
+	n, err := w.ReadFrom(src)
+	if err != nil {
+		t.Fatalf("ReadFrom error: %v", err)
+	}
+	if n != int64(len(data)) {
+		t.Fatalf("ReadFrom = %d, want %d", n, len(data))
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush error: %v", err)
+	}
+	if dst.String() != string(data) {
+		t.Fatalf("dst has %d bytes, want %d", dst.Len(), len(data))
+	}
+}
+
+func TestWriterReadFromGenericFallback(t *testing.T) {
+	data := bytes.Repeat([]byte("z"), 100)
+	src := &plainReader{r: bytes.NewReader(data)}
+	var dst bytes.Buffer
+	w := NewWriterSize[byte](&dst, 8)
+
+	n, err := w.ReadFrom(src)
+	if err != nil {
+		t.Fatalf("ReadFrom error: %v", err)
+	}
+	if n != int64(len(data)) {
+		t.Fatalf("ReadFrom = %d, want %d", n, len(data))
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush error: %v", err)
+	}
+	if dst.String() != string(data) {
+		t.Fatalf("dst has %d bytes, want %d", dst.Len(), len(data))
+	}
+}